@@ -0,0 +1,127 @@
+package vsphere
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereHostNetworkHints() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostNetworkHintsRead,
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The managed object ID of the host to query network hints on.",
+			},
+			"physical_nics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The physical NICs to query hints for. If omitted, hints are returned for all of the host's physical NICs.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"hints": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The CDP/LLDP neighbor data observed on each physical NIC.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"device": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the physical NIC that this hint was observed on.",
+						},
+						"connected_switch_port": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The port ID on the peer switch that this NIC is connected to.",
+						},
+						"peer_device": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the peer device (switch) as reported by CDP/LLDP.",
+						},
+						"vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The native VLAN ID reported by the peer device, if any.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenPhysicalNicHintInfo converts a single types.PhysicalNicHintInfo
+// into the map form expected by the "hints" schema above, preferring CDP
+// data over LLDP when both are present.
+//
+// Neither ConnectedSwitchPort (CDP) nor LldpInfo (LLDP) exposes MTU, so the
+// "hints" schema does not have a field for it. LLDP also doesn't surface the
+// peer system name or VLAN as dedicated fields - they're carried as
+// key/value TLVs in Parameter, whose Value is an AnyType and is skipped here
+// if it isn't a string.
+func flattenPhysicalNicHintInfo(hint types.PhysicalNicHintInfo) map[string]interface{} {
+	m := map[string]interface{}{
+		"device": hint.Device,
+	}
+
+	switch {
+	case hint.ConnectedSwitchPort != nil:
+		cdp := hint.ConnectedSwitchPort
+		m["connected_switch_port"] = cdp.PortId
+		m["peer_device"] = cdp.DevId
+		m["vlan_id"] = int(cdp.Vlan)
+	case hint.LldpInfo != nil:
+		lldp := hint.LldpInfo
+		m["connected_switch_port"] = lldp.PortId
+		m["peer_device"] = lldp.ChassisId
+		for _, p := range lldp.Parameter {
+			sv, ok := p.Value.(string)
+			if !ok {
+				continue
+			}
+			switch p.Key {
+			case "System Name":
+				m["peer_device"] = sv
+			case "Port VLAN Id":
+				if vlan, err := strconv.Atoi(sv); err == nil {
+					m["vlan_id"] = vlan
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func dataSourceVSphereHostNetworkHintsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	pnics := sliceInterfacesToStrings(d.Get("physical_nics").([]interface{}))
+	rawHints, err := queryHostNetworkHints(client, ns, pnics)
+	if err != nil {
+		return err
+	}
+
+	hints := make([]interface{}, 0, len(rawHints))
+	for _, h := range rawHints {
+		hints = append(hints, flattenPhysicalNicHintInfo(h))
+	}
+	if err := d.Set("hints", hints); err != nil {
+		return fmt.Errorf("error setting hints: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-network-hints", hsID))
+	return nil
+}
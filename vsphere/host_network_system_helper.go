@@ -3,6 +3,8 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
@@ -11,6 +13,70 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 )
 
+// networkInfoCacheTTL is the length of time a cached
+// mo.HostNetworkSystem.NetworkInfo entry is considered fresh before
+// hostNetworkInfo re-fetches it from vCenter/ESXi.
+const networkInfoCacheTTL = 30 * time.Second
+
+// networkInfoCacheEntry holds a single cached NetworkInfo and the time it
+// was fetched.
+type networkInfoCacheEntry struct {
+	info    types.HostNetworkInfo
+	fetched time.Time
+}
+
+// networkInfoCache is a short-lived cache of HostNetworkSystem.NetworkInfo,
+// keyed by HostNetworkSystem managed object reference, for a single
+// *govmomi.Client. A single VM resource with several NICs on the same host
+// would otherwise force a separate RetrieveOne round-trip per NIC during a
+// single plan/apply; this cache collapses them into one fetch per host per
+// networkInfoCacheTTL.
+type networkInfoCache struct {
+	mu      sync.Mutex
+	entries map[string]networkInfoCacheEntry
+}
+
+// networkInfoCachesByClient holds one networkInfoCache per *govmomi.Client
+// (map[*govmomi.Client]*networkInfoCache), i.e. one per provider
+// configuration/session. HostNetworkSystem MoRef values are not globally
+// unique - every standalone ESXi host uses the well-known value
+// "ha-network-system", and values can collide across distinct vCenters as
+// well - so caching per client keeps two provider instances, even ones
+// pointed at the same endpoint under different credentials, from ever
+// serving each other's NetworkInfo.
+var networkInfoCachesByClient sync.Map
+
+// networkInfoCacheFor returns the networkInfoCache for client, creating one
+// on first use.
+func networkInfoCacheFor(client *govmomi.Client) *networkInfoCache {
+	v, _ := networkInfoCachesByClient.LoadOrStore(client, &networkInfoCache{
+		entries: make(map[string]networkInfoCacheEntry),
+	})
+	return v.(*networkInfoCache)
+}
+
+func (c *networkInfoCache) get(key string) (types.HostNetworkInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetched) > networkInfoCacheTTL {
+		return types.HostNetworkInfo{}, false
+	}
+	return entry.info, true
+}
+
+func (c *networkInfoCache) set(key string, info types.HostNetworkInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = networkInfoCacheEntry{info: info, fetched: time.Now()}
+}
+
+func (c *networkInfoCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
 // hostNetworkSystemFromHostSystem locates a HostNetworkSystem from a specified
 // HostSystem.
 func hostNetworkSystemFromHostSystem(hs *object.HostSystem) (*object.HostNetworkSystem, error) {
@@ -29,18 +95,45 @@ func hostNetworkSystemFromHostSystemID(client *govmomi.Client, hsID string) (*ob
 	return hostNetworkSystemFromHostSystem(hs)
 }
 
-// hostVSwitchFromName locates a virtual switch on the supplied
-// HostNetworkSystem by name.
-func hostVSwitchFromName(client *govmomi.Client, ns *object.HostNetworkSystem, name string) (*types.HostVirtualSwitch, error) {
+// hostNetworkInfo returns the full NetworkInfo for the supplied
+// HostNetworkSystem, serving it from client's networkInfoCache when a fresh
+// entry is available rather than issuing a new RetrieveOne for every call.
+func hostNetworkInfo(client *govmomi.Client, ns *object.HostNetworkSystem) (*types.HostNetworkInfo, error) {
+	cache := networkInfoCacheFor(client)
+	key := ns.Reference().Value
+	if info, ok := cache.get(key); ok {
+		return &info, nil
+	}
+
 	var mns mo.HostNetworkSystem
 	pc := client.PropertyCollector()
 	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
 	defer cancel()
-	if err := pc.RetrieveOne(ctx, ns.Reference(), []string{"networkInfo.vswitch"}, &mns); err != nil {
+	if err := pc.RetrieveOne(ctx, ns.Reference(), []string{"networkInfo"}, &mns); err != nil {
 		return nil, fmt.Errorf("error fetching host network properties: %s", err)
 	}
 
-	for _, sw := range mns.NetworkInfo.Vswitch {
+	cache.set(key, mns.NetworkInfo)
+	return &mns.NetworkInfo, nil
+}
+
+// RefreshNetworkSystem invalidates any cached NetworkInfo for ns on client's
+// cache. Any code path that mutates vswitches or port groups on ns
+// (AddVirtualSwitch, UpdatePortGroup, RemoveVirtualSwitch, etc.) must call
+// this before a subsequent read is expected to observe the change.
+func RefreshNetworkSystem(client *govmomi.Client, ns *object.HostNetworkSystem) {
+	networkInfoCacheFor(client).invalidate(ns.Reference().Value)
+}
+
+// hostVSwitchFromName locates a virtual switch on the supplied
+// HostNetworkSystem by name.
+func hostVSwitchFromName(client *govmomi.Client, ns *object.HostNetworkSystem, name string) (*types.HostVirtualSwitch, error) {
+	info, err := hostNetworkInfo(client, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sw := range info.Vswitch {
 		if sw.Name == name {
 			return &sw, nil
 		}
@@ -52,15 +145,12 @@ func hostVSwitchFromName(client *govmomi.Client, ns *object.HostNetworkSystem, n
 // hostPortGroupFromName locates a port group on the supplied HostNetworkSystem
 // by name.
 func hostPortGroupFromName(client *govmomi.Client, ns *object.HostNetworkSystem, name string) (*types.HostPortGroup, error) {
-	var mns mo.HostNetworkSystem
-	pc := client.PropertyCollector()
-	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
-	defer cancel()
-	if err := pc.RetrieveOne(ctx, ns.Reference(), []string{"networkInfo.portgroup"}, &mns); err != nil {
-		return nil, fmt.Errorf("error fetching host network properties: %s", err)
+	info, err := hostNetworkInfo(client, ns)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, pg := range mns.NetworkInfo.Portgroup {
+	for _, pg := range info.Portgroup {
 		if pg.Spec.Name == name {
 			return &pg, nil
 		}
@@ -69,6 +159,94 @@ func hostPortGroupFromName(client *govmomi.Client, ns *object.HostNetworkSystem,
 	return nil, fmt.Errorf("could not find port group %s", name)
 }
 
+// addHostVirtualSwitch creates a new standard virtual switch on ns per spec,
+// wrapping HostNetworkSystem.AddVirtualSwitch.
+func addHostVirtualSwitch(client *govmomi.Client, ns *object.HostNetworkSystem, name string, spec *types.HostVirtualSwitchSpec) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.AddVirtualSwitch(ctx, name, spec); err != nil {
+		return fmt.Errorf("error adding virtual switch %q: %s", name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// updateHostVirtualSwitch updates the named standard virtual switch on ns to
+// match spec, wrapping HostNetworkSystem.UpdateVirtualSwitch.
+func updateHostVirtualSwitch(client *govmomi.Client, ns *object.HostNetworkSystem, name string, spec types.HostVirtualSwitchSpec) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.UpdateVirtualSwitch(ctx, name, spec); err != nil {
+		return fmt.Errorf("error updating virtual switch %q: %s", name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// removeHostVirtualSwitch removes the named standard virtual switch from ns,
+// wrapping HostNetworkSystem.RemoveVirtualSwitch.
+func removeHostVirtualSwitch(client *govmomi.Client, ns *object.HostNetworkSystem, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.RemoveVirtualSwitch(ctx, name); err != nil {
+		return fmt.Errorf("error removing virtual switch %q: %s", name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// addHostPortGroup creates a new standard port group on ns per spec,
+// wrapping HostNetworkSystem.AddPortGroup.
+func addHostPortGroup(client *govmomi.Client, ns *object.HostNetworkSystem, spec types.HostPortGroupSpec) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.AddPortGroup(ctx, spec); err != nil {
+		return fmt.Errorf("error adding port group %q: %s", spec.Name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// updateHostPortGroup updates the named standard port group on ns to match
+// spec, wrapping HostNetworkSystem.UpdatePortGroup.
+func updateHostPortGroup(client *govmomi.Client, ns *object.HostNetworkSystem, name string, spec types.HostPortGroupSpec) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.UpdatePortGroup(ctx, name, spec); err != nil {
+		return fmt.Errorf("error updating port group %q: %s", name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// removeHostPortGroup removes the named standard port group from ns,
+// wrapping HostNetworkSystem.RemovePortGroup.
+func removeHostPortGroup(client *govmomi.Client, ns *object.HostNetworkSystem, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.RemovePortGroup(ctx, name); err != nil {
+		return fmt.Errorf("error removing port group %q: %s", name, err)
+	}
+	RefreshNetworkSystem(client, ns)
+	return nil
+}
+
+// queryHostNetworkHints returns the CDP/LLDP neighbor data that ns has
+// observed on the supplied physical NICs, wrapping
+// HostNetworkSystem.QueryNetworkHint. A nil or empty pnics queries hints for
+// all of the host's physical NICs.
+func queryHostNetworkHints(client *govmomi.Client, ns *object.HostNetworkSystem, pnics []string) ([]types.PhysicalNicHintInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+
+	hints, err := ns.QueryNetworkHint(ctx, pnics)
+	if err != nil {
+		return nil, fmt.Errorf("error querying network hints: %s", err)
+	}
+
+	return hints, nil
+}
+
 // networkProperties gets the properties for a specific Network.
 //
 // The Network type usually represents a standard port group in vCenter - it
@@ -127,3 +305,142 @@ func networkObjectFromHostSystem(client *govmomi.Client, hs *object.HostSystem,
 
 	return nil, fmt.Errorf("could not find a matching %q on host ID %q", name, hs.Reference().Value)
 }
+
+// dvsPortgroupFromName locates a DistributedVirtualPortgroup in vCenter by
+// name. Unlike networkObjectFromHostSystem, this is not scoped to a specific
+// HostSystem, since a DVS port group is not tied to an individual host the
+// way a standard port group is.
+func dvsPortgroupFromName(client *govmomi.Client, name string) (*object.DistributedVirtualPortgroup, error) {
+	if err := validateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	finder := find.NewFinder(client.Client, false)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	nets, err := finder.NetworkList(ctx, "*/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range nets {
+		pg, ok := n.(*object.DistributedVirtualPortgroup)
+		if !ok {
+			// Not a DVS port group (possibly a standard port group, etc), pass
+			continue
+		}
+		return pg, nil
+	}
+
+	return nil, fmt.Errorf("could not find distributed virtual port group %q", name)
+}
+
+// dvsFromName locates the DistributedVirtualSwitch that backs the
+// distributed virtual port group of the supplied name.
+func dvsFromName(client *govmomi.Client, name string) (*object.VmwareDistributedVirtualSwitch, error) {
+	pg, err := dvsPortgroupFromName(client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var pgProps mo.DistributedVirtualPortgroup
+	if err := pg.Properties(ctx, pg.Reference(), nil, &pgProps); err != nil {
+		return nil, fmt.Errorf("error fetching port group properties: %s", err)
+	}
+	if pgProps.Config.DistributedVirtualSwitch == nil {
+		return nil, fmt.Errorf("port group %q has no parent switch", name)
+	}
+
+	return object.NewVmwareDistributedVirtualSwitch(client.Client, *pgProps.Config.DistributedVirtualSwitch), nil
+}
+
+// networkKind discriminates the kind of network located by networkFromName.
+type networkKind string
+
+const (
+	networkKindStandard    networkKind = "standard"
+	networkKindDistributed networkKind = "distributed"
+)
+
+// networkFromNameResult is the discriminated result returned by
+// networkFromName. Only one of Network or DVPortgroup is populated,
+// depending on Kind.
+type networkFromNameResult struct {
+	// Kind denotes whether the located network is backed by a standard
+	// HostPortGroup or a DistributedVirtualPortgroup.
+	Kind networkKind
+
+	// Network is populated when Kind is networkKindStandard.
+	Network *object.Network
+
+	// DVPortgroup is populated when Kind is networkKindDistributed.
+	DVPortgroup *object.DistributedVirtualPortgroup
+
+	// DVSUUID is the UUID of the DistributedVirtualSwitch backing
+	// DVPortgroup. It is only populated when Kind is networkKindDistributed,
+	// and is required to build a
+	// types.VirtualEthernetCardDistributedVirtualPortBackingInfo.
+	DVSUUID string
+}
+
+// BackingInfo builds the VirtualDeviceBackingInfo appropriate for attaching a
+// VM NIC to the located network, regardless of whether it's a standard or
+// DVS-backed port group.
+func (r *networkFromNameResult) BackingInfo() types.BaseVirtualDeviceBackingInfo {
+	switch r.Kind {
+	case networkKindDistributed:
+		return &types.VirtualEthernetCardDistributedVirtualPortBackingInfo{
+			Port: types.DistributedVirtualSwitchPortConnection{
+				PortgroupKey: r.DVPortgroup.Reference().Value,
+				SwitchUuid:   r.DVSUUID,
+			},
+		}
+	default:
+		return &types.VirtualEthernetCardNetworkBackingInfo{
+			VirtualDeviceDeviceBackingInfo: types.VirtualDeviceDeviceBackingInfo{
+				DeviceName: r.Network.Name(),
+			},
+		}
+	}
+}
+
+// networkFromName locates the network of the supplied name associated with
+// hs, whether it's backed by a standard port group or a distributed virtual
+// port group, and returns a discriminated result suitable for VM NIC
+// attachment. Standard port groups are tried first via
+// networkObjectFromHostSystem, as that is the more common case and is scoped
+// to the host; DVS port groups are tried second, since they are not scoped
+// to an individual host.
+func networkFromName(client *govmomi.Client, hs *object.HostSystem, name string) (*networkFromNameResult, error) {
+	if net, err := networkObjectFromHostSystem(client, hs, name); err == nil {
+		return &networkFromNameResult{Kind: networkKindStandard, Network: net}, nil
+	}
+
+	pg, err := dvsPortgroupFromName(client, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not find a standard or distributed virtual network named %q on host ID %q", name, hs.Reference().Value)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var pgProps mo.DistributedVirtualPortgroup
+	if err := pg.Properties(ctx, pg.Reference(), nil, &pgProps); err != nil {
+		return nil, fmt.Errorf("error fetching port group properties: %s", err)
+	}
+	if pgProps.Config.DistributedVirtualSwitch == nil {
+		return nil, fmt.Errorf("port group %q has no parent switch", name)
+	}
+
+	var dvsProps mo.DistributedVirtualSwitch
+	dvs := object.NewDistributedVirtualSwitch(client.Client, *pgProps.Config.DistributedVirtualSwitch)
+	if err := dvs.Properties(ctx, dvs.Reference(), []string{"uuid"}, &dvsProps); err != nil {
+		return nil, fmt.Errorf("error fetching distributed virtual switch properties: %s", err)
+	}
+
+	return &networkFromNameResult{
+		Kind:        networkKindDistributed,
+		DVPortgroup: pg,
+		DVSUUID:     dvsProps.Uuid,
+	}, nil
+}
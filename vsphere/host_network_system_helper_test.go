@@ -0,0 +1,250 @@
+package vsphere
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+)
+
+// simulatorServer is the in-process vCenter/ESXi model started in TestMain.
+// Tests in this file connect to it instead of a live vCenter, so that the
+// host network helpers can be exercised in CI without any VMware
+// infrastructure.
+var simulatorServer *simulator.Server
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	model := simulator.VPX()
+	model.Host = 1
+	model.Datastore = 1
+	model.Portgroup = 1
+	model.DVS = 1
+	model.Pool = 1
+	if err := model.Create(); err != nil {
+		fmt.Fprintf(os.Stderr, "error creating simulator model: %s\n", err)
+		os.Exit(1)
+	}
+	defer model.Remove()
+
+	simulatorServer = model.Service.NewServer()
+	defer simulatorServer.Close()
+
+	os.Exit(m.Run())
+}
+
+// testAccPreCheckSimulator returns a *govmomi.Client connected to the
+// in-process vCenter simulator started in TestMain. It is the simulator
+// equivalent of the testAccPreCheck used by the provider's acceptance tests,
+// but does not require VSPHERE_* environment variables or a reachable
+// vCenter/ESXi endpoint.
+func testAccPreCheckSimulator(t *testing.T) *govmomi.Client {
+	t.Helper()
+
+	u := simulatorServer.URL
+	creds := url.UserPassword("user", "pass")
+	u.User = creds
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+
+	client, err := govmomi.NewClient(ctx, u, true)
+	if err != nil {
+		t.Fatalf("error connecting to simulator: %s", err)
+	}
+
+	return client
+}
+
+// testHostSystemFromSimulator returns the default HostSystem of the simulator
+// inventory, as resolved by finder.DefaultHostSystem.
+func testHostSystemFromSimulator(t *testing.T, client *govmomi.Client) *object.HostSystem {
+	t.Helper()
+
+	finder := find.NewFinder(client.Client, false)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+
+	hs, err := finder.DefaultHostSystem(ctx)
+	if err != nil {
+		t.Fatalf("error locating default host system: %s", err)
+	}
+
+	return hs
+}
+
+func TestHostVSwitchFromName(t *testing.T) {
+	client := testAccPreCheckSimulator(t)
+	hs := testHostSystemFromSimulator(t, client)
+	ns, err := hostNetworkSystemFromHostSystem(hs)
+	if err != nil {
+		t.Fatalf("error locating host network system: %s", err)
+	}
+
+	cases := []struct {
+		name      string
+		vswitch   string
+		expectErr bool
+	}{
+		{"existing standard vswitch", "vSwitch0", false},
+		{"missing vswitch", "vSwitchDoesNotExist", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sw, err := hostVSwitchFromName(client, ns, tc.vswitch)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if sw.Name != tc.vswitch {
+				t.Fatalf("expected vswitch named %q, got %q", tc.vswitch, sw.Name)
+			}
+		})
+	}
+}
+
+func TestHostPortGroupFromName(t *testing.T) {
+	client := testAccPreCheckSimulator(t)
+	hs := testHostSystemFromSimulator(t, client)
+	ns, err := hostNetworkSystemFromHostSystem(hs)
+	if err != nil {
+		t.Fatalf("error locating host network system: %s", err)
+	}
+
+	cases := []struct {
+		name      string
+		portgroup string
+		expectErr bool
+	}{
+		{"existing standard port group", "VM Network", false},
+		{"missing port group", "PortGroupDoesNotExist", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pg, err := hostPortGroupFromName(client, ns, tc.portgroup)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if pg.Spec.Name != tc.portgroup {
+				t.Fatalf("expected port group named %q, got %q", tc.portgroup, pg.Spec.Name)
+			}
+		})
+	}
+}
+
+func TestNetworkObjectFromHostSystem(t *testing.T) {
+	client := testAccPreCheckSimulator(t)
+	hs := testHostSystemFromSimulator(t, client)
+
+	cases := []struct {
+		name      string
+		network   string
+		expectErr bool
+	}{
+		{"existing standard network", "VM Network", false},
+		{"DVS-backed network is not a standard network", "DC0_DVPG0", true},
+		{"missing network", "NetworkDoesNotExist", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := networkObjectFromHostSystem(client, hs, tc.network)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestDVSPortgroupFromName(t *testing.T) {
+	client := testAccPreCheckSimulator(t)
+
+	cases := []struct {
+		name      string
+		portgroup string
+		expectErr bool
+	}{
+		{"existing DVS port group", "DC0_DVPG0", false},
+		{"standard port group is not a DVS port group", "VM Network", true},
+		{"missing port group", "PortGroupDoesNotExist", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pg, err := dvsPortgroupFromName(client, tc.portgroup)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if pg.Name() != tc.portgroup {
+				t.Fatalf("expected DVS port group named %q, got %q", tc.portgroup, pg.Name())
+			}
+		})
+	}
+}
+
+func TestNetworkFromName(t *testing.T) {
+	client := testAccPreCheckSimulator(t)
+	hs := testHostSystemFromSimulator(t, client)
+
+	cases := []struct {
+		name      string
+		network   string
+		wantKind  networkKind
+		expectErr bool
+	}{
+		{"standard port group resolves to standard kind", "VM Network", networkKindStandard, false},
+		{"DVS port group resolves to distributed kind", "DC0_DVPG0", networkKindDistributed, false},
+		{"missing network errors", "NetworkDoesNotExist", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := networkFromName(client, hs, tc.network)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if res.Kind != tc.wantKind {
+				t.Fatalf("expected kind %q, got %q", tc.wantKind, res.Kind)
+			}
+		})
+	}
+}
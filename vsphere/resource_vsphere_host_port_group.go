@@ -0,0 +1,221 @@
+package vsphere
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func resourceVSphereHostPortGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostPortGroupCreate,
+		Read:   resourceVSphereHostPortGroupRead,
+		Update: resourceVSphereHostPortGroupUpdate,
+		Delete: resourceVSphereHostPortGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostPortGroupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to set the port group up on.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the port group.",
+			},
+			"virtual_switch_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the virtual switch to bind this port group to.",
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The VLAN ID/trunk mode for this port group.",
+			},
+			"allow_promiscuous": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable promiscuous mode on the network. This flag indicates whether or not all traffic is seen on a given port.",
+			},
+			"allow_forged_transmits": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Controls whether or not the virtual network adapter is allowed to send network traffic with a different MAC address than that of its own.",
+			},
+			"allow_mac_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Controls whether or not the Media Access Control (MAC) address can be changed.",
+			},
+			"shaping_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable traffic shaping on this port group.",
+			},
+			"shaping_average_bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The average bandwidth, in bits per second, if traffic shaping is enabled on this port group.",
+			},
+			"shaping_peak_bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The peak bandwidth, in bits per second, during bursts if traffic shaping is enabled on this port group.",
+			},
+			"shaping_burst_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum burst size, in bytes, allowed if traffic shaping is enabled on this port group.",
+			},
+		},
+	}
+}
+
+// expandHostPortGroupSpec reads the fields out of ResourceData and builds a
+// types.HostPortGroupSpec suitable for addHostPortGroup/updateHostPortGroup.
+func expandHostPortGroupSpec(d *schema.ResourceData) types.HostPortGroupSpec {
+	return types.HostPortGroupSpec{
+		Name:        d.Get("name").(string),
+		VswitchName: d.Get("virtual_switch_name").(string),
+		VlanId:      int32(d.Get("vlan_id").(int)),
+		Policy: types.HostNetworkPolicy{
+			Security: &types.HostNetworkSecurityPolicy{
+				AllowPromiscuous: structBoolPtr(d.Get("allow_promiscuous").(bool)),
+				MacChanges:       structBoolPtr(d.Get("allow_mac_changes").(bool)),
+				ForgedTransmits:  structBoolPtr(d.Get("allow_forged_transmits").(bool)),
+			},
+			ShapingPolicy: &types.HostNetworkTrafficShapingPolicy{
+				Enabled:          structBoolPtr(d.Get("shaping_enabled").(bool)),
+				AverageBandwidth: int64(d.Get("shaping_average_bandwidth").(int)),
+				PeakBandwidth:    int64(d.Get("shaping_peak_bandwidth").(int)),
+				BurstSize:        int64(d.Get("shaping_burst_size").(int)),
+			},
+		},
+	}
+}
+
+// flattenHostPortGroupSpec reads the fields of a types.HostPortGroupSpec back
+// into ResourceData.
+func flattenHostPortGroupSpec(d *schema.ResourceData, spec *types.HostPortGroupSpec) error {
+	d.Set("virtual_switch_name", spec.VswitchName)
+	d.Set("vlan_id", spec.VlanId)
+
+	if sec := spec.Policy.Security; sec != nil {
+		setBoolPtr(d, "allow_promiscuous", sec.AllowPromiscuous)
+		setBoolPtr(d, "allow_mac_changes", sec.MacChanges)
+		setBoolPtr(d, "allow_forged_transmits", sec.ForgedTransmits)
+	}
+	if shaping := spec.Policy.ShapingPolicy; shaping != nil {
+		setBoolPtr(d, "shaping_enabled", shaping.Enabled)
+		d.Set("shaping_average_bandwidth", shaping.AverageBandwidth)
+		d.Set("shaping_peak_bandwidth", shaping.PeakBandwidth)
+		d.Set("shaping_burst_size", shaping.BurstSize)
+	}
+
+	return nil
+}
+
+func resourceVSphereHostPortGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	if err := addHostPortGroup(client, ns, expandHostPortGroupSpec(d)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", hsID, name))
+	return resourceVSphereHostPortGroupRead(d, meta)
+}
+
+func resourceVSphereHostPortGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	pg, err := hostPortGroupFromName(client, ns, name)
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	return flattenHostPortGroupSpec(d, &pg.Spec)
+}
+
+func resourceVSphereHostPortGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	return updateHostPortGroup(client, ns, name, expandHostPortGroupSpec(d))
+}
+
+func resourceVSphereHostPortGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	return removeHostPortGroup(client, ns, name)
+}
+
+// resourceVSphereHostPortGroupImport parses an ID of the form
+// "<host-moid>:<name>" and hydrates state using hostPortGroupFromName.
+func resourceVSphereHostPortGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("ID must be of the form <host-moid>:<name>")
+	}
+
+	client := meta.(*VSphereClient).vimClient
+	hsID, name := parts[0], parts[1]
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	pg, err := hostPortGroupFromName(client, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("host_system_id", hsID)
+	d.Set("name", name)
+	d.Set("virtual_switch_name", pg.Spec.VswitchName)
+	d.SetId(d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,273 @@
+package vsphere
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func resourceVSphereHostVirtualSwitch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostVirtualSwitchCreate,
+		Read:   resourceVSphereHostVirtualSwitchRead,
+		Update: resourceVSphereHostVirtualSwitchUpdate,
+		Delete: resourceVSphereHostVirtualSwitchDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostVirtualSwitchImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to set the virtual switch up on.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the virtual switch.",
+			},
+			"active_nics": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "List of active network adapters used for load balancing.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"standby_nics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of standby network adapters used for failover.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"teaming_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "loadbalance_srcid",
+				Description: "The network adapter teaming policy used by the virtual switch.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1500,
+				Description: "The maximum transmission unit (MTU) for the virtual switch.",
+			},
+			"number_of_ports": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     128,
+				Description: "The number of ports that this virtual switch is configured to use.",
+			},
+			"allow_promiscuous": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable promiscuous mode on the network.",
+			},
+			"allow_forged_transmits": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Controls whether or not the virtual network adapter is allowed to send network traffic with a different MAC address than that of its own.",
+			},
+			"allow_mac_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Controls whether or not the Media Access Control (MAC) address can be changed.",
+			},
+			"shaping_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable traffic shaping on this virtual switch.",
+			},
+			"shaping_average_bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The average bandwidth, in bits per second, if traffic shaping is enabled on this virtual switch.",
+			},
+			"shaping_peak_bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The peak bandwidth, in bits per second, during bursts if traffic shaping is enabled on this virtual switch.",
+			},
+			"shaping_burst_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum burst size, in bytes, allowed if traffic shaping is enabled on this virtual switch.",
+			},
+		},
+	}
+}
+
+// expandHostVirtualSwitchSpec reads the fields out of ResourceData and
+// builds a types.HostVirtualSwitchSpec suitable for
+// addHostVirtualSwitch/updateHostVirtualSwitch.
+func expandHostVirtualSwitchSpec(d *schema.ResourceData) *types.HostVirtualSwitchSpec {
+	activeNics := sliceInterfacesToStrings(d.Get("active_nics").([]interface{}))
+	standbyNics := sliceInterfacesToStrings(d.Get("standby_nics").([]interface{}))
+
+	spec := &types.HostVirtualSwitchSpec{
+		NumPorts: int32(d.Get("number_of_ports").(int)),
+		Mtu:      int32(d.Get("mtu").(int)),
+		Bridge: &types.HostVirtualSwitchBondBridge{
+			// Every NIC referenced in the teaming order, active or standby,
+			// must also be part of the bond bridge, or AddVirtualSwitch and
+			// UpdateVirtualSwitch reject the spec.
+			NicDevice: append(append([]string{}, activeNics...), standbyNics...),
+		},
+		Policy: &types.HostNetworkPolicy{
+			Nicteaming: &types.HostNicTeamingPolicy{
+				Policy: &types.HostNicTeamingPolicyConfig{
+					Policy: d.Get("teaming_policy").(string),
+				},
+				NicOrder: &types.HostNicOrderPolicy{
+					ActiveNic:  activeNics,
+					StandbyNic: standbyNics,
+				},
+			},
+			Security: &types.HostNetworkSecurityPolicy{
+				AllowPromiscuous: structBoolPtr(d.Get("allow_promiscuous").(bool)),
+				MacChanges:       structBoolPtr(d.Get("allow_mac_changes").(bool)),
+				ForgedTransmits:  structBoolPtr(d.Get("allow_forged_transmits").(bool)),
+			},
+			ShapingPolicy: &types.HostNetworkTrafficShapingPolicy{
+				Enabled:          structBoolPtr(d.Get("shaping_enabled").(bool)),
+				AverageBandwidth: int64(d.Get("shaping_average_bandwidth").(int)),
+				PeakBandwidth:    int64(d.Get("shaping_peak_bandwidth").(int)),
+				BurstSize:        int64(d.Get("shaping_burst_size").(int)),
+			},
+		},
+	}
+
+	return spec
+}
+
+// flattenHostVirtualSwitchSpec reads the fields of a
+// types.HostVirtualSwitchSpec back into ResourceData.
+func flattenHostVirtualSwitchSpec(d *schema.ResourceData, spec *types.HostVirtualSwitchSpec) error {
+	d.Set("mtu", spec.Mtu)
+	d.Set("number_of_ports", spec.NumPorts)
+
+	if bridge, ok := spec.Bridge.(*types.HostVirtualSwitchBondBridge); ok {
+		d.Set("active_nics", bridge.NicDevice)
+	}
+
+	if policy := spec.Policy; policy != nil {
+		if nt := policy.Nicteaming; nt != nil {
+			if nt.Policy != nil {
+				d.Set("teaming_policy", nt.Policy.Policy)
+			}
+			if nt.NicOrder != nil {
+				d.Set("active_nics", nt.NicOrder.ActiveNic)
+				d.Set("standby_nics", nt.NicOrder.StandbyNic)
+			}
+		}
+		if sec := policy.Security; sec != nil {
+			setBoolPtr(d, "allow_promiscuous", sec.AllowPromiscuous)
+			setBoolPtr(d, "allow_mac_changes", sec.MacChanges)
+			setBoolPtr(d, "allow_forged_transmits", sec.ForgedTransmits)
+		}
+		if shaping := policy.ShapingPolicy; shaping != nil {
+			setBoolPtr(d, "shaping_enabled", shaping.Enabled)
+			d.Set("shaping_average_bandwidth", shaping.AverageBandwidth)
+			d.Set("shaping_peak_bandwidth", shaping.PeakBandwidth)
+			d.Set("shaping_burst_size", shaping.BurstSize)
+		}
+	}
+
+	return nil
+}
+
+func resourceVSphereHostVirtualSwitchCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	if err := addHostVirtualSwitch(client, ns, name, expandHostVirtualSwitchSpec(d)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", hsID, name))
+	return resourceVSphereHostVirtualSwitchRead(d, meta)
+}
+
+func resourceVSphereHostVirtualSwitchRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	sw, err := hostVSwitchFromName(client, ns, name)
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	return flattenHostVirtualSwitchSpec(d, &sw.Spec)
+}
+
+func resourceVSphereHostVirtualSwitchUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	return updateHostVirtualSwitch(client, ns, name, *expandHostVirtualSwitchSpec(d))
+}
+
+func resourceVSphereHostVirtualSwitchDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	return removeHostVirtualSwitch(client, ns, name)
+}
+
+// resourceVSphereHostVirtualSwitchImport parses an ID of the form
+// "<host-moid>:<name>" and hydrates state using hostVSwitchFromName.
+func resourceVSphereHostVirtualSwitchImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("ID must be of the form <host-moid>:<name>")
+	}
+
+	client := meta.(*VSphereClient).vimClient
+	hsID, name := parts[0], parts[1]
+
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	if _, err := hostVSwitchFromName(client, ns, name); err != nil {
+		return nil, err
+	}
+
+	d.Set("host_system_id", hsID)
+	d.Set("name", name)
+	d.SetId(d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
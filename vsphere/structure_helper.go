@@ -0,0 +1,40 @@
+package vsphere
+
+import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+// sliceInterfacesToStrings converts an interface slice, as you would get
+// from a schema.TypeList of schema.TypeString, to a string slice.
+func sliceInterfacesToStrings(s []interface{}) []string {
+	result := make([]string, len(s))
+	for i, v := range s {
+		result[i] = v.(string)
+	}
+	return result
+}
+
+// sliceStringsToInterfaces converts a string slice to an interface slice
+// suitable for setting into a schema.TypeList of schema.TypeString.
+func sliceStringsToInterfaces(s []string) []interface{} {
+	result := make([]interface{}, len(s))
+	for i, v := range s {
+		result[i] = v
+	}
+	return result
+}
+
+// structBoolPtr returns a pointer to the supplied bool, for use in govmomi
+// structs that represent an unset value as a nil *bool.
+func structBoolPtr(b bool) *bool {
+	return &b
+}
+
+// setBoolPtr sets key in d from b, but only when b is non-nil. govmomi
+// represents an inherited (not explicitly set) policy value as a nil *bool;
+// leaving the key untouched in that case avoids stomping a schema default of
+// true with a false read off of an inherited value.
+func setBoolPtr(d *schema.ResourceData, key string, b *bool) {
+	if b == nil {
+		return
+	}
+	d.Set(key, *b)
+}